@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/daniyalumer/go-collect-system-metrics-cronjob/alerting"
+)
+
+const alertStateFile = "./reports/.alert_state.json"
+
+// alertHistoryWindow bounds how many recent rows are read from the rolling
+// CSV to render in a firing/resolved notification.
+const alertHistoryWindow = 20
+
+// alertSinksFromEnv builds the configured notification sinks: SMTP (reusing
+// this tool's existing SMTP_* settings) and, if ALERT_WEBHOOK_URL is set, a
+// generic JSON webhook for Slack/Discord/etc.
+func alertSinksFromEnv() []alerting.Sink {
+	var sinks []alerting.Sink
+
+	if os.Getenv("SMTP_HOST") != "" {
+		port, err := strconv.Atoi(os.Getenv("SMTP_PORT"))
+		if err != nil {
+			log.Printf("alerting: invalid SMTP_PORT, skipping SMTP sink: %v\n", err)
+		} else {
+			sinks = append(sinks, alerting.SMTPSink{
+				Host:     os.Getenv("SMTP_HOST"),
+				Port:     port,
+				User:     os.Getenv("SMTP_USER"),
+				Password: os.Getenv("SMTP_PASSWORD"),
+				From:     os.Getenv("SMTP_FROM"),
+				To:       os.Getenv("SMTP_TO"),
+			})
+		}
+	}
+
+	if url := os.Getenv("ALERT_WEBHOOK_URL"); url != "" {
+		sinks = append(sinks, alerting.WebhookSink{URL: url})
+	}
+
+	return sinks
+}
+
+// evaluateAlerts checks the latest sample against any ALERT_*_GT thresholds
+// declared in env and notifies configured sinks on state transitions.
+func evaluateAlerts(metrics *SystemMetrics) {
+	rules := alerting.RulesFromEnv()
+	if len(rules) == 0 {
+		return
+	}
+
+	evaluator, err := alerting.NewEvaluator(alertStateFile, alertSinksFromEnv()...)
+	if err != nil {
+		log.Printf("alerting: error loading state: %v\n", err)
+		return
+	}
+
+	history := recentAlertHistory()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	evaluator.Evaluate(ctx, alerting.Metrics{
+		Timestamp:   metrics.Timestamp,
+		CPUUsage:    metrics.CPUUsage,
+		MemoryUsage: metrics.MemoryUsage,
+		DiskUsage:   metrics.DiskUsage,
+	}, history)
+}
+
+// recentAlertHistory reads the last alertHistoryWindow rows of the past 24
+// hours from the rolling daily CSVs for inclusion in notifications.
+func recentAlertHistory() []alerting.Metrics {
+	rows, err := readMetricsRows(time.Now().Add(-24*time.Hour), time.Now())
+	if err != nil {
+		log.Printf("alerting: error reading history: %v\n", err)
+		return nil
+	}
+
+	if len(rows) > alertHistoryWindow {
+		rows = rows[len(rows)-alertHistoryWindow:]
+	}
+
+	history := make([]alerting.Metrics, 0, len(rows))
+	for _, row := range rows {
+		ts, err := time.Parse(time.RFC3339, row[0])
+		if err != nil {
+			continue
+		}
+		cpuUsage, _ := strconv.ParseFloat(row[2], 64)
+		memUsage, _ := strconv.ParseFloat(row[3], 64)
+		diskUsage, _ := strconv.ParseFloat(row[7], 64)
+		history = append(history, alerting.Metrics{Timestamp: ts, CPUUsage: cpuUsage, MemoryUsage: memUsage, DiskUsage: diskUsage})
+	}
+	return history
+}
@@ -0,0 +1,81 @@
+// Package alerting evaluates collected metrics against user-declared
+// thresholds and dispatches notifications through pluggable sinks (SMTP,
+// webhook) when a rule fires or resolves.
+package alerting
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// Metrics is the subset of a collected sample that rules can be evaluated
+// against.
+type Metrics struct {
+	Timestamp   time.Time
+	CPUUsage    float64
+	MemoryUsage float64
+	DiskUsage   float64
+}
+
+// Rule is a single threshold declared via env, e.g. ALERT_CPU_GT=90.
+type Rule struct {
+	// Name identifies the rule in state and notifications, e.g. "cpu".
+	Name string
+	// Value extracts the metric this rule watches from a Metrics sample.
+	Value func(Metrics) float64
+	// Threshold is the value Value must exceed to start counting toward
+	// Sustained.
+	Threshold float64
+	// Sustained is how long Value must stay above Threshold before the
+	// rule fires.
+	Sustained time.Duration
+}
+
+// Exceeds reports whether m violates the rule's threshold.
+func (r Rule) Exceeds(m Metrics) bool {
+	return r.Value(m) > r.Threshold
+}
+
+// RulesFromEnv builds the rule set from ALERT_CPU_GT, ALERT_MEM_GT,
+// ALERT_DISK_GT and the shared ALERT_FOR sustain window. A threshold that
+// isn't set is omitted, so users only pay for the rules they declare.
+func RulesFromEnv() []Rule {
+	sustained := envDuration("ALERT_FOR", 5*time.Minute)
+
+	var rules []Rule
+	if v, ok := envFloat("ALERT_CPU_GT"); ok {
+		rules = append(rules, Rule{Name: "cpu", Value: func(m Metrics) float64 { return m.CPUUsage }, Threshold: v, Sustained: sustained})
+	}
+	if v, ok := envFloat("ALERT_MEM_GT"); ok {
+		rules = append(rules, Rule{Name: "memory", Value: func(m Metrics) float64 { return m.MemoryUsage }, Threshold: v, Sustained: sustained})
+	}
+	if v, ok := envFloat("ALERT_DISK_GT"); ok {
+		rules = append(rules, Rule{Name: "disk", Value: func(m Metrics) float64 { return m.DiskUsage }, Threshold: v, Sustained: sustained})
+	}
+	return rules
+}
+
+func envFloat(key string) (float64, bool) {
+	v := os.Getenv(key)
+	if v == "" {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+func envDuration(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return fallback
+	}
+	return d
+}
@@ -0,0 +1,82 @@
+package alerting
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Evaluator checks each collected sample against Rules and dispatches a
+// Notification to every Sink the first time a rule starts/stops firing
+// (hysteresis), persisting progress to State so repeated cron invocations
+// don't re-fire on every tick.
+type Evaluator struct {
+	Rules []Rule
+	Sinks []Sink
+	State *State
+}
+
+// NewEvaluator builds an Evaluator from env-declared rules and the given
+// sinks, loading (or creating) its state file at statePath.
+func NewEvaluator(statePath string, sinks ...Sink) (*Evaluator, error) {
+	state, err := LoadState(statePath)
+	if err != nil {
+		return nil, err
+	}
+	return &Evaluator{Rules: RulesFromEnv(), Sinks: sinks, State: state}, nil
+}
+
+// Evaluate checks the latest sample against every rule and fires/resolves
+// notifications as needed. history provides recent context rendered into
+// the notification (e.g. the SMTP sink's table).
+func (e *Evaluator) Evaluate(ctx context.Context, sample Metrics, history []Metrics) {
+	changed := false
+
+	for _, rule := range e.Rules {
+		state := e.State.Rules[rule.Name]
+		exceeds := rule.Exceeds(sample)
+
+		switch {
+		case exceeds && !state.Firing:
+			if state.ExceededSince.IsZero() {
+				state.ExceededSince = sample.Timestamp
+			}
+			if sample.Timestamp.Sub(state.ExceededSince) >= rule.Sustained {
+				state.Firing = true
+				e.notify(ctx, rule, sample, history, state.ExceededSince, false)
+			}
+
+		case !exceeds && state.Firing:
+			state.Firing = false
+			state.ExceededSince = sample.Timestamp
+			e.notify(ctx, rule, sample, history, sample.Timestamp, true)
+
+		case !exceeds:
+			state.ExceededSince = sample.Timestamp
+		}
+
+		e.State.Rules[rule.Name] = state
+		changed = true
+	}
+
+	if changed {
+		if err := e.State.Save(); err != nil {
+			log.Printf("alerting: error saving state: %v\n", err)
+		}
+	}
+}
+
+func (e *Evaluator) notify(ctx context.Context, rule Rule, sample Metrics, history []Metrics, since time.Time, resolved bool) {
+	n := Notification{
+		Rule:     rule,
+		Value:    rule.Value(sample),
+		Resolved: resolved,
+		Since:    since,
+		History:  history,
+	}
+	for _, sink := range e.Sinks {
+		if err := sink.Send(ctx, n); err != nil {
+			log.Printf("alerting: error notifying %s for rule %s: %v\n", sink, rule.Name, err)
+		}
+	}
+}
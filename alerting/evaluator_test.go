@@ -0,0 +1,107 @@
+package alerting
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// recordingSink collects every Notification it receives, for assertions.
+type recordingSink struct {
+	notifications []Notification
+}
+
+func (s *recordingSink) Send(ctx context.Context, n Notification) error {
+	s.notifications = append(s.notifications, n)
+	return nil
+}
+
+func newTestEvaluator(t *testing.T, sink Sink, rule Rule) *Evaluator {
+	t.Helper()
+	state, err := LoadState(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	return &Evaluator{Rules: []Rule{rule}, Sinks: []Sink{sink}, State: state}
+}
+
+func TestEvaluatorFiresAfterSustainedWindow(t *testing.T) {
+	rule := Rule{Name: "cpu", Value: func(m Metrics) float64 { return m.CPUUsage }, Threshold: 90, Sustained: 5 * time.Minute}
+	sink := &recordingSink{}
+	e := newTestEvaluator(t, sink, rule)
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Exceeds threshold, but not sustained long enough yet - should not fire.
+	e.Evaluate(context.Background(), Metrics{Timestamp: base, CPUUsage: 95}, nil)
+	if len(sink.notifications) != 0 {
+		t.Fatalf("expected no notification before sustain window elapsed, got %d", len(sink.notifications))
+	}
+
+	// Still exceeding once Sustained has elapsed - should fire exactly once.
+	e.Evaluate(context.Background(), Metrics{Timestamp: base.Add(5 * time.Minute), CPUUsage: 95}, nil)
+	if len(sink.notifications) != 1 {
+		t.Fatalf("expected 1 notification after sustain window elapsed, got %d", len(sink.notifications))
+	}
+	if sink.notifications[0].Resolved {
+		t.Fatalf("expected firing notification, got resolved")
+	}
+
+	// Still exceeding on the next tick - must not re-fire (hysteresis).
+	e.Evaluate(context.Background(), Metrics{Timestamp: base.Add(6 * time.Minute), CPUUsage: 95}, nil)
+	if len(sink.notifications) != 1 {
+		t.Fatalf("expected no repeat notification while already firing, got %d", len(sink.notifications))
+	}
+}
+
+func TestEvaluatorResolvesOnceWhenBackUnderThreshold(t *testing.T) {
+	rule := Rule{Name: "cpu", Value: func(m Metrics) float64 { return m.CPUUsage }, Threshold: 90, Sustained: time.Minute}
+	sink := &recordingSink{}
+	e := newTestEvaluator(t, sink, rule)
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	e.Evaluate(context.Background(), Metrics{Timestamp: base, CPUUsage: 95}, nil)
+	e.Evaluate(context.Background(), Metrics{Timestamp: base.Add(time.Minute), CPUUsage: 95}, nil)
+	if len(sink.notifications) != 1 {
+		t.Fatalf("expected rule to have fired, got %d notifications", len(sink.notifications))
+	}
+
+	e.Evaluate(context.Background(), Metrics{Timestamp: base.Add(2 * time.Minute), CPUUsage: 10}, nil)
+	if len(sink.notifications) != 2 {
+		t.Fatalf("expected a resolved notification, got %d", len(sink.notifications))
+	}
+	if !sink.notifications[1].Resolved {
+		t.Fatalf("expected second notification to be resolved")
+	}
+
+	// Staying under threshold must not resolve again.
+	e.Evaluate(context.Background(), Metrics{Timestamp: base.Add(3 * time.Minute), CPUUsage: 10}, nil)
+	if len(sink.notifications) != 2 {
+		t.Fatalf("expected no repeat resolved notification, got %d", len(sink.notifications))
+	}
+}
+
+func TestEvaluatorTracksExceededSinceFromLastGoodSample(t *testing.T) {
+	rule := Rule{Name: "cpu", Value: func(m Metrics) float64 { return m.CPUUsage }, Threshold: 90, Sustained: 5 * time.Minute}
+	sink := &recordingSink{}
+	e := newTestEvaluator(t, sink, rule)
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// ExceededSince is measured from the last sample that was NOT
+	// exceeding, not from when the current streak of exceeding samples
+	// began - so a brief dip followed by a fresh spike still counts
+	// elapsed time from the dip once Sustained has passed.
+	e.Evaluate(context.Background(), Metrics{Timestamp: base, CPUUsage: 95}, nil)
+	e.Evaluate(context.Background(), Metrics{Timestamp: base.Add(time.Minute), CPUUsage: 10}, nil)
+	e.Evaluate(context.Background(), Metrics{Timestamp: base.Add(2 * time.Minute), CPUUsage: 95}, nil)
+	if len(sink.notifications) != 0 {
+		t.Fatalf("expected no notification only 1 minute after the last good sample, got %d", len(sink.notifications))
+	}
+
+	e.Evaluate(context.Background(), Metrics{Timestamp: base.Add(6 * time.Minute), CPUUsage: 95}, nil)
+	if len(sink.notifications) != 1 {
+		t.Fatalf("expected a notification once 5 minutes have passed since the last good sample, got %d", len(sink.notifications))
+	}
+}
@@ -0,0 +1,114 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"time"
+
+	gomail "gopkg.in/mail.v2"
+)
+
+// Notification describes a single rule firing or resolving, including
+// enough recent history to render useful context.
+type Notification struct {
+	Rule     Rule
+	Value    float64
+	Resolved bool
+	Since    time.Time
+	History  []Metrics
+}
+
+// Sink dispatches a Notification. Implementations should be safe to call
+// from the evaluator synchronously; SMTP/webhook calls already carry their
+// own timeouts.
+type Sink interface {
+	Send(ctx context.Context, n Notification) error
+}
+
+// SMTPSink emails notifications using the same gomail dialer pattern as
+// the rest of this tool.
+type SMTPSink struct {
+	Host, User, Password, From, To string
+	Port                           int
+}
+
+var emailTemplate = template.Must(template.New("alert").Parse(`
+<h2>{{if .Resolved}}Resolved{{else}}Firing{{end}}: {{.Rule.Name}}</h2>
+<p>Current value: {{printf "%.2f" .Value}} (threshold {{printf "%.2f" .Rule.Threshold}}, sustained {{.Rule.Sustained}})</p>
+<p>{{if .Resolved}}Back under threshold as of {{.Since}}{{else}}Exceeding threshold since {{.Since}}{{end}}</p>
+<table border="1" cellpadding="4" cellspacing="0">
+<tr><th>Timestamp</th><th>CPU%</th><th>Memory%</th><th>Disk%</th></tr>
+{{range .History}}<tr><td>{{.Timestamp}}</td><td>{{printf "%.2f" .CPUUsage}}</td><td>{{printf "%.2f" .MemoryUsage}}</td><td>{{printf "%.2f" .DiskUsage}}</td></tr>
+{{end}}</table>
+`))
+
+func (s SMTPSink) Send(ctx context.Context, n Notification) error {
+	var body bytes.Buffer
+	if err := emailTemplate.Execute(&body, n); err != nil {
+		return fmt.Errorf("render alert template: %w", err)
+	}
+
+	message := gomail.NewMessage()
+	message.SetHeader("From", s.From)
+	message.SetHeader("To", s.To)
+	message.SetHeader("Subject", alertSubject(n))
+	message.SetBody("text/html", body.String())
+
+	dialer := gomail.NewDialer(s.Host, s.Port, s.User, s.Password)
+	return dialer.DialAndSend(message)
+}
+
+func alertSubject(n Notification) string {
+	if n.Resolved {
+		return fmt.Sprintf("[RESOLVED] %s alert", n.Rule.Name)
+	}
+	return fmt.Sprintf("[FIRING] %s alert: %s", n.Rule.Name, strconv.FormatFloat(n.Value, 'f', 2, 64))
+}
+
+// WebhookSink POSTs a JSON payload to a generic endpoint (Slack, Discord,
+// PagerDuty, etc. all accept a plain JSON POST via an incoming webhook).
+type WebhookSink struct {
+	URL    string
+	Client *http.Client
+}
+
+func (s WebhookSink) Send(ctx context.Context, n Notification) error {
+	payload := map[string]any{
+		"rule":      n.Rule.Name,
+		"value":     n.Value,
+		"threshold": n.Rule.Threshold,
+		"resolved":  n.Resolved,
+		"since":     n.Since.Format(time.RFC3339),
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package alerting
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ruleState tracks, per rule, whether it is currently firing and the time its
+// sustain window is measured from (the last sample that was not exceeding,
+// or the first exceeding sample if none came before) - so state survives
+// across cron invocations.
+type ruleState struct {
+	Firing        bool      `json:"firing"`
+	ExceededSince time.Time `json:"exceeded_since,omitempty"`
+}
+
+// State is the on-disk hysteresis state for every rule, keyed by rule name.
+type State struct {
+	path  string
+	Rules map[string]ruleState `json:"rules"`
+}
+
+// LoadState reads the state file at path, returning an empty State if it
+// doesn't exist yet.
+func LoadState(path string) (*State, error) {
+	state := &State{path: path, Rules: map[string]ruleState{}}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	state.path = path
+	return state, nil
+}
+
+// Save persists the state file.
+func (s *State) Save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
@@ -0,0 +1,56 @@
+// Package collector implements per-subsystem metric collectors in the style
+// of node_exporter: each subsystem (cpu, mem, disk, load, net, filesystem)
+// implements the Collector interface and is responsible for gathering its
+// own samples and emitting them as prometheus.Metric values.
+package collector
+
+import (
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector is implemented by every subsystem collector. Update is called
+// once per scrape/tick and should send zero or more metrics on ch.
+type Collector interface {
+	// Name identifies the collector, e.g. "cpu" or "filesystem".
+	Name() string
+	// Update gathers fresh samples and sends them on ch.
+	Update(ch chan<- prometheus.Metric) error
+}
+
+// All returns the default set of collectors enabled by this tool.
+func All() []Collector {
+	return []Collector{
+		NewCPUCollector(),
+		NewMemCollector(),
+		NewDiskCollector(),
+		NewLoadCollector(),
+		NewNetCollector(),
+		NewFilesystemCollector(),
+	}
+}
+
+// registry bridges the Collector interface to prometheus.Collector so the
+// set can be registered with a prometheus.Registry or promhttp.Handler.
+type registry struct {
+	collectors []Collector
+}
+
+// NewRegistry wraps collectors as a single prometheus.Collector.
+func NewRegistry(collectors ...Collector) prometheus.Collector {
+	return &registry{collectors: collectors}
+}
+
+func (r *registry) Describe(ch chan<- *prometheus.Desc) {
+	// Descriptions are unknown ahead of time for dynamically-labeled metrics
+	// (e.g. per-mount filesystem usage), so this collector is unchecked.
+}
+
+func (r *registry) Collect(ch chan<- prometheus.Metric) {
+	for _, c := range r.collectors {
+		if err := c.Update(ch); err != nil {
+			log.Printf("collector %s: %v\n", c.Name(), err)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package collector
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shirou/gopsutil/v4/cpu"
+)
+
+type cpuCollector struct {
+	usage *prometheus.Desc
+	count *prometheus.Desc
+}
+
+// NewCPUCollector reports overall CPU usage percentage and logical core count.
+func NewCPUCollector() Collector {
+	return &cpuCollector{
+		usage: prometheus.NewDesc("node_cpu_usage_percent", "Total CPU usage percentage.", nil, nil),
+		count: prometheus.NewDesc("node_cpu_count", "Number of logical CPUs.", nil, nil),
+	}
+}
+
+func (c *cpuCollector) Name() string { return "cpu" }
+
+func (c *cpuCollector) Update(ch chan<- prometheus.Metric) error {
+	percent, err := cpu.Percent(time.Second, false)
+	if err != nil {
+		return err
+	}
+
+	count, err := cpu.Counts(true)
+	if err != nil {
+		return err
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.usage, prometheus.GaugeValue, percent[0])
+	ch <- prometheus.MustNewConstMetric(c.count, prometheus.GaugeValue, float64(count))
+	return nil
+}
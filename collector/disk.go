@@ -0,0 +1,39 @@
+package collector
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shirou/gopsutil/v4/disk"
+)
+
+type diskCollector struct {
+	usagePercent *prometheus.Desc
+	total        *prometheus.Desc
+	free         *prometheus.Desc
+	used         *prometheus.Desc
+}
+
+// NewDiskCollector reports usage of the root filesystem. Per-mount usage is
+// handled separately by the filesystem collector.
+func NewDiskCollector() Collector {
+	return &diskCollector{
+		usagePercent: prometheus.NewDesc("node_disk_usage_percent", "Root filesystem used percentage.", nil, nil),
+		total:        prometheus.NewDesc("node_disk_total_bytes", "Root filesystem total bytes.", nil, nil),
+		free:         prometheus.NewDesc("node_disk_free_bytes", "Root filesystem free bytes.", nil, nil),
+		used:         prometheus.NewDesc("node_disk_used_bytes", "Root filesystem used bytes.", nil, nil),
+	}
+}
+
+func (c *diskCollector) Name() string { return "disk" }
+
+func (c *diskCollector) Update(ch chan<- prometheus.Metric) error {
+	u, err := disk.Usage("/")
+	if err != nil {
+		return err
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.usagePercent, prometheus.GaugeValue, u.UsedPercent)
+	ch <- prometheus.MustNewConstMetric(c.total, prometheus.GaugeValue, float64(u.Total))
+	ch <- prometheus.MustNewConstMetric(c.free, prometheus.GaugeValue, float64(u.Free))
+	ch <- prometheus.MustNewConstMetric(c.used, prometheus.GaugeValue, float64(u.Used))
+	return nil
+}
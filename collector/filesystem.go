@@ -0,0 +1,49 @@
+package collector
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shirou/gopsutil/v4/disk"
+)
+
+type filesystemCollector struct {
+	usagePercent *prometheus.Desc
+	total        *prometheus.Desc
+	free         *prometheus.Desc
+	used         *prometheus.Desc
+}
+
+// NewFilesystemCollector reports usage for every mounted, physical
+// filesystem, labeled by mountpoint and fstype.
+func NewFilesystemCollector() Collector {
+	labels := []string{"mountpoint", "fstype"}
+	return &filesystemCollector{
+		usagePercent: prometheus.NewDesc("node_filesystem_usage_percent", "Filesystem used percentage.", labels, nil),
+		total:        prometheus.NewDesc("node_filesystem_size_bytes", "Filesystem total bytes.", labels, nil),
+		free:         prometheus.NewDesc("node_filesystem_free_bytes", "Filesystem free bytes.", labels, nil),
+		used:         prometheus.NewDesc("node_filesystem_used_bytes", "Filesystem used bytes.", labels, nil),
+	}
+}
+
+func (c *filesystemCollector) Name() string { return "filesystem" }
+
+func (c *filesystemCollector) Update(ch chan<- prometheus.Metric) error {
+	partitions, err := disk.Partitions(false)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range partitions {
+		usage, err := disk.Usage(p.Mountpoint)
+		if err != nil {
+			// Some mounts (e.g. bind mounts, special filesystems) can be
+			// unreadable; skip rather than fail the whole collector.
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.usagePercent, prometheus.GaugeValue, usage.UsedPercent, p.Mountpoint, p.Fstype)
+		ch <- prometheus.MustNewConstMetric(c.total, prometheus.GaugeValue, float64(usage.Total), p.Mountpoint, p.Fstype)
+		ch <- prometheus.MustNewConstMetric(c.free, prometheus.GaugeValue, float64(usage.Free), p.Mountpoint, p.Fstype)
+		ch <- prometheus.MustNewConstMetric(c.used, prometheus.GaugeValue, float64(usage.Used), p.Mountpoint, p.Fstype)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package collector
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shirou/gopsutil/v4/load"
+)
+
+type loadCollector struct {
+	load1  *prometheus.Desc
+	load5  *prometheus.Desc
+	load15 *prometheus.Desc
+}
+
+// NewLoadCollector reports the 1/5/15 minute system load averages.
+func NewLoadCollector() Collector {
+	return &loadCollector{
+		load1:  prometheus.NewDesc("node_load1", "1m load average.", nil, nil),
+		load5:  prometheus.NewDesc("node_load5", "5m load average.", nil, nil),
+		load15: prometheus.NewDesc("node_load15", "15m load average.", nil, nil),
+	}
+}
+
+func (c *loadCollector) Name() string { return "load" }
+
+func (c *loadCollector) Update(ch chan<- prometheus.Metric) error {
+	avg, err := load.Avg()
+	if err != nil {
+		return err
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.load1, prometheus.GaugeValue, avg.Load1)
+	ch <- prometheus.MustNewConstMetric(c.load5, prometheus.GaugeValue, avg.Load5)
+	ch <- prometheus.MustNewConstMetric(c.load15, prometheus.GaugeValue, avg.Load15)
+	return nil
+}
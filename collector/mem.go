@@ -0,0 +1,38 @@
+package collector
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shirou/gopsutil/v4/mem"
+)
+
+type memCollector struct {
+	usagePercent *prometheus.Desc
+	total        *prometheus.Desc
+	free         *prometheus.Desc
+	used         *prometheus.Desc
+}
+
+// NewMemCollector reports virtual memory usage.
+func NewMemCollector() Collector {
+	return &memCollector{
+		usagePercent: prometheus.NewDesc("node_memory_usage_percent", "Memory used percentage.", nil, nil),
+		total:        prometheus.NewDesc("node_memory_total_bytes", "Total memory in bytes.", nil, nil),
+		free:         prometheus.NewDesc("node_memory_free_bytes", "Free memory in bytes.", nil, nil),
+		used:         prometheus.NewDesc("node_memory_used_bytes", "Used memory in bytes.", nil, nil),
+	}
+}
+
+func (c *memCollector) Name() string { return "mem" }
+
+func (c *memCollector) Update(ch chan<- prometheus.Metric) error {
+	v, err := mem.VirtualMemory()
+	if err != nil {
+		return err
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.usagePercent, prometheus.GaugeValue, v.UsedPercent)
+	ch <- prometheus.MustNewConstMetric(c.total, prometheus.GaugeValue, float64(v.Total))
+	ch <- prometheus.MustNewConstMetric(c.free, prometheus.GaugeValue, float64(v.Free))
+	ch <- prometheus.MustNewConstMetric(c.used, prometheus.GaugeValue, float64(v.Used))
+	return nil
+}
@@ -0,0 +1,35 @@
+package collector
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shirou/gopsutil/v4/net"
+)
+
+type netCollector struct {
+	bytesRecv *prometheus.Desc
+	bytesSent *prometheus.Desc
+}
+
+// NewNetCollector reports cumulative bytes sent/received per network
+// interface.
+func NewNetCollector() Collector {
+	return &netCollector{
+		bytesRecv: prometheus.NewDesc("node_network_receive_bytes_total", "Bytes received.", []string{"device"}, nil),
+		bytesSent: prometheus.NewDesc("node_network_transmit_bytes_total", "Bytes transmitted.", []string{"device"}, nil),
+	}
+}
+
+func (c *netCollector) Name() string { return "net" }
+
+func (c *netCollector) Update(ch chan<- prometheus.Metric) error {
+	counters, err := net.IOCounters(true)
+	if err != nil {
+		return err
+	}
+
+	for _, counter := range counters {
+		ch <- prometheus.MustNewConstMetric(c.bytesRecv, prometheus.CounterValue, float64(counter.BytesRecv), counter.Name)
+		ch <- prometheus.MustNewConstMetric(c.bytesSent, prometheus.CounterValue, float64(counter.BytesSent), counter.Name)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/daniyalumer/go-collect-system-metrics-cronjob/collector"
+	"github.com/daniyalumer/go-collect-system-metrics-cronjob/pkg/pushmetrics"
+	"github.com/joho/godotenv"
+)
+
+const (
+	defaultCollectInterval = 10 * time.Second
+	defaultEmailInterval   = time.Hour
+	shutdownTimeout        = 30 * time.Second
+)
+
+// runDaemon replaces the one-shot cron invocation with a long-running
+// process: it collects on COLLECT_INTERVAL, emails on the slower
+// EMAIL_INTERVAL, and shuts down cleanly on SIGINT/SIGTERM. SIGHUP reloads
+// the .env file and re-applies any changed intervals without a restart.
+func runDaemon() {
+	collectInterval := envDuration("COLLECT_INTERVAL", defaultCollectInterval)
+	emailInterval := envDuration("EMAIL_INTERVAL", defaultEmailInterval)
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+
+	collectTicker := time.NewTicker(collectInterval)
+	defer collectTicker.Stop()
+
+	if os.Getenv("HTTP_API_ADDR") != "" {
+		go runHTTPAPI()
+	}
+	if os.Getenv("PROM_LISTEN_ADDR") != "" {
+		go runPromScrape()
+	}
+
+	// Daemon mode composes with whichever push/export mode is configured,
+	// same as the one-shot cron path: PUSH_MODE=grpc streams each tick,
+	// REMOTE_WRITE_URL pushes each tick, and otherwise ticks fall back to
+	// the CSV+alerting+digest behavior. A long-lived gRPC client here also
+	// means the replay ring buffer is reused across ticks instead of being
+	// rebuilt (and persisted/reloaded) on every invocation.
+	var grpcClient *pushmetrics.Client
+	var remoteWriteClient *collector.RemoteWriteClient
+	switch {
+	case os.Getenv("PUSH_MODE") == "grpc":
+		grpcClient = pushmetrics.NewClient(pushmetrics.ConfigFromEnv())
+		log.Println("daemon: streaming metrics via gRPC on each tick")
+	case os.Getenv("REMOTE_WRITE_URL") != "":
+		remoteWriteClient = collector.NewRemoteWriteClient(os.Getenv("REMOTE_WRITE_URL"), os.Getenv("REMOTE_WRITE_USERNAME"), os.Getenv("REMOTE_WRITE_PASSWORD"))
+		log.Println("daemon: pushing metrics via remote_write on each tick")
+	}
+
+	// The digest email only has anything to summarize when collectTick is
+	// appending to the rolling CSV, which it doesn't do in gRPC/remote_write
+	// mode - so don't schedule it there; it would otherwise fire every
+	// EMAIL_INTERVAL and log a "no samples" error forever.
+	var emailTicker *time.Ticker
+	var emailTickerC <-chan time.Time
+	if grpcClient == nil && remoteWriteClient == nil {
+		emailTicker = time.NewTicker(emailInterval)
+		defer emailTicker.Stop()
+		emailTickerC = emailTicker.C
+	}
+
+	digestSince := time.Now()
+
+	// emailMu is held for the duration of each SMTP send so shutdown can
+	// wait for an in-flight DialAndSend to finish before exiting.
+	var emailMu sync.Mutex
+
+	log.Println("Starting daemon mode")
+	for {
+		select {
+		case <-collectTicker.C:
+			collectTick(grpcClient, remoteWriteClient)
+
+		case <-emailTickerC:
+			since := digestSince
+			digestSince = time.Now()
+			go func() {
+				emailMu.Lock()
+				defer emailMu.Unlock()
+				stats, err := computeDigest(since)
+				if err != nil {
+					log.Printf("Error computing metrics digest: %v\n", err)
+					return
+				}
+				log.Println("Sending metrics digest email")
+				if err := sendDigestEmail(stats); err != nil {
+					log.Printf("Error sending digest email: %v\n", err)
+				}
+			}()
+
+		case sig := <-sigCh:
+			if sig == syscall.SIGHUP {
+				reloadConfig(&collectInterval, &emailInterval, collectTicker, emailTicker)
+				continue
+			}
+			log.Printf("Received %v, shutting down\n", sig)
+			waitForInFlightEmail(&emailMu)
+			return
+		}
+	}
+}
+
+// collectTick runs one collection cycle. If grpcClient or remoteWriteClient
+// is set, the sample is streamed/pushed to that endpoint instead of being
+// appended to the rolling CSV, mirroring the one-shot cron path's mode
+// selection.
+func collectTick(grpcClient *pushmetrics.Client, remoteWriteClient *collector.RemoteWriteClient) {
+	switch {
+	case grpcClient != nil:
+		metrics, err := getSystemMetrics()
+		if err != nil {
+			log.Printf("Error getting system metrics: %v\n", err)
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := grpcClient.Send(ctx, pushSampleFromMetrics(metrics)); err != nil {
+			log.Printf("Error streaming metrics via gRPC: %v\n", err)
+		}
+
+	case remoteWriteClient != nil:
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := remoteWriteClient.Push(ctx, collector.All()); err != nil {
+			log.Printf("Error pushing metrics via remote_write: %v\n", err)
+		}
+
+	default:
+		metrics, err := getSystemMetrics()
+		if err != nil {
+			log.Printf("Error getting system metrics: %v\n", err)
+			return
+		}
+		log.Printf("Metrics: %+v\n", metrics)
+		saveSystemMetrics(metrics, rollingCSVPath())
+		evaluateAlerts(metrics)
+	}
+}
+
+// reloadConfig re-reads the .env file on SIGHUP and resets any ticker whose
+// interval changed.
+func reloadConfig(collectInterval, emailInterval *time.Duration, collectTicker, emailTicker *time.Ticker) {
+	log.Println("Received SIGHUP, reloading config")
+	if err := godotenv.Overload(); err != nil {
+		log.Printf("Error reloading .env file: %v\n", err)
+		return
+	}
+
+	if next := envDuration("COLLECT_INTERVAL", *collectInterval); next != *collectInterval {
+		*collectInterval = next
+		collectTicker.Reset(next)
+		log.Printf("COLLECT_INTERVAL changed to %v\n", next)
+	}
+	if next := envDuration("EMAIL_INTERVAL", *emailInterval); next != *emailInterval {
+		*emailInterval = next
+		if emailTicker != nil {
+			emailTicker.Reset(next)
+		}
+		log.Printf("EMAIL_INTERVAL changed to %v\n", next)
+	}
+}
+
+// waitForInFlightEmail blocks until the email mutex is free (i.e. any
+// DialAndSend in progress has completed) or shutdownTimeout elapses.
+func waitForInFlightEmail(emailMu *sync.Mutex) {
+	done := make(chan struct{})
+	go func() {
+		emailMu.Lock()
+		defer emailMu.Unlock()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(shutdownTimeout):
+		log.Println("Timed out waiting for in-flight email to complete")
+	}
+}
+
+func envDuration(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Printf("Invalid duration for %s=%q, using %v: %v\n", key, v, fallback, err)
+		return fallback
+	}
+	return d
+}
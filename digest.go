@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	gomail "gopkg.in/mail.v2"
+)
+
+const (
+	defaultDigestInterval = time.Hour
+	lastDigestStateFile   = "./reports/.last_digest"
+)
+
+// digestStats summarizes the samples appended to the rolling CSV over a
+// window, for inclusion in the periodic email digest.
+type digestStats struct {
+	Since, Until time.Time
+	Samples      int
+	CPUMin       float64
+	CPUMax       float64
+	CPUAvg       float64
+	MemMin       float64
+	MemMax       float64
+	MemAvg       float64
+	DiskMin      float64
+	DiskMax      float64
+	DiskAvg      float64
+	// Files is every rolling CSV that contributed at least one sample in
+	// the window, oldest first, so the digest email can attach all of
+	// them instead of just today's.
+	Files []string
+}
+
+// computeDigest summarizes every sample timestamped at or after since,
+// reading across every rolling daily CSV that falls in range - not just
+// today's - so a window spanning midnight doesn't silently drop yesterday's
+// samples.
+func computeDigest(since time.Time) (*digestStats, error) {
+	rows, files, err := readMetricsFiles(since, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no samples since %s", since.Format(time.RFC3339))
+	}
+
+	stats := &digestStats{
+		Since:   since,
+		Until:   time.Now(),
+		CPUMin:  math.MaxFloat64,
+		MemMin:  math.MaxFloat64,
+		DiskMin: math.MaxFloat64,
+		Files:   files,
+	}
+	var cpuSum, memSum, diskSum float64
+
+	for _, row := range rows {
+		cpuUsage, _ := strconv.ParseFloat(row[2], 64)
+		memUsage, _ := strconv.ParseFloat(row[3], 64)
+		diskUsage, _ := strconv.ParseFloat(row[7], 64)
+
+		stats.CPUMin = math.Min(stats.CPUMin, cpuUsage)
+		stats.CPUMax = math.Max(stats.CPUMax, cpuUsage)
+		stats.MemMin = math.Min(stats.MemMin, memUsage)
+		stats.MemMax = math.Max(stats.MemMax, memUsage)
+		stats.DiskMin = math.Min(stats.DiskMin, diskUsage)
+		stats.DiskMax = math.Max(stats.DiskMax, diskUsage)
+
+		cpuSum += cpuUsage
+		memSum += memUsage
+		diskSum += diskUsage
+		stats.Samples++
+	}
+
+	stats.CPUAvg = cpuSum / float64(stats.Samples)
+	stats.MemAvg = memSum / float64(stats.Samples)
+	stats.DiskAvg = diskSum / float64(stats.Samples)
+	return stats, nil
+}
+
+// sendDigestEmail emails stats as an HTML+plaintext multipart message, with
+// every CSV it was computed from (stats.Files) attached.
+func sendDigestEmail(stats *digestStats) error {
+	plain := fmt.Sprintf(
+		"System metrics digest: %s to %s (%d samples)\n\n"+
+			"CPU usage:    min %.2f%%  avg %.2f%%  max %.2f%%\n"+
+			"Memory usage: min %.2f%%  avg %.2f%%  max %.2f%%\n"+
+			"Disk usage:   min %.2f%%  avg %.2f%%  max %.2f%%\n",
+		stats.Since.Format(time.RFC3339), stats.Until.Format(time.RFC3339), stats.Samples,
+		stats.CPUMin, stats.CPUAvg, stats.CPUMax,
+		stats.MemMin, stats.MemAvg, stats.MemMax,
+		stats.DiskMin, stats.DiskAvg, stats.DiskMax,
+	)
+
+	html := fmt.Sprintf(`<h2>System Metrics Digest</h2>
+<p>%s to %s (%d samples)</p>
+<table border="1" cellpadding="4" cellspacing="0">
+<tr><th>Metric</th><th>Min</th><th>Avg</th><th>Max</th></tr>
+<tr><td>CPU usage</td><td>%.2f%%</td><td>%.2f%%</td><td>%.2f%%</td></tr>
+<tr><td>Memory usage</td><td>%.2f%%</td><td>%.2f%%</td><td>%.2f%%</td></tr>
+<tr><td>Disk usage</td><td>%.2f%%</td><td>%.2f%%</td><td>%.2f%%</td></tr>
+</table>`,
+		stats.Since.Format(time.RFC3339), stats.Until.Format(time.RFC3339), stats.Samples,
+		stats.CPUMin, stats.CPUAvg, stats.CPUMax,
+		stats.MemMin, stats.MemAvg, stats.MemMax,
+		stats.DiskMin, stats.DiskAvg, stats.DiskMax,
+	)
+
+	message := gomail.NewMessage()
+	message.SetHeader("From", os.Getenv("SMTP_FROM"))
+	message.SetHeader("To", os.Getenv("SMTP_TO"))
+	message.SetHeader("Subject", fmt.Sprintf("System Metrics Digest - %s", stats.Until.Format("2006-01-02 15:04")))
+	message.SetBody("text/plain", plain)
+	message.AddAlternative("text/html", html)
+	for _, path := range stats.Files {
+		message.Attach(path)
+	}
+
+	smtpPort, err := strconv.Atoi(os.Getenv("SMTP_PORT"))
+	if err != nil {
+		return fmt.Errorf("invalid SMTP port: %w", err)
+	}
+
+	dialer := gomail.NewDialer(
+		os.Getenv("SMTP_HOST"),
+		smtpPort,
+		os.Getenv("SMTP_USER"),
+		os.Getenv("SMTP_PASSWORD"),
+	)
+	return dialer.DialAndSend(message)
+}
+
+// sendDigestIfDue sends a digest email when DIGEST_INTERVAL has elapsed
+// since the last one, tracked via a timestamp file in ./reports so the
+// cadence survives across one-shot cron invocations.
+func sendDigestIfDue() {
+	interval := envDuration("DIGEST_INTERVAL", defaultDigestInterval)
+
+	last, err := lastDigestTime()
+	if err == nil && time.Since(last) < interval {
+		return
+	}
+
+	since := time.Now().Add(-interval)
+	stats, err := computeDigest(since)
+	if err != nil {
+		log.Printf("Error computing metrics digest: %v\n", err)
+		return
+	}
+
+	log.Println("Sending metrics digest email")
+	if err := sendDigestEmail(stats); err != nil {
+		log.Printf("Error sending digest email: %v\n", err)
+		return
+	}
+
+	if err := writeLastDigestTime(time.Now()); err != nil {
+		log.Printf("Error recording last digest time: %v\n", err)
+	}
+}
+
+func lastDigestTime() (time.Time, error) {
+	data, err := os.ReadFile(lastDigestStateFile)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339, string(data))
+}
+
+func writeLastDigestTime(t time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(lastDigestStateFile), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(lastDigestStateFile, []byte(t.Format(time.RFC3339)), 0644)
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func writeMetricsCSV(t *testing.T, dir, date string, rows [][]string) {
+	t.Helper()
+	path := filepath.Join(dir, "metrics_"+date+".csv")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create %s: %v", path, err)
+	}
+	defer f.Close()
+
+	f.WriteString(joinCSV(csvHeader) + "\n")
+	for _, row := range rows {
+		f.WriteString(joinCSV(row) + "\n")
+	}
+}
+
+func joinCSV(fields []string) string {
+	out := ""
+	for i, f := range fields {
+		if i > 0 {
+			out += ","
+		}
+		out += f
+	}
+	return out
+}
+
+func row(ts time.Time, cpu, mem, disk float64) []string {
+	return []string{ts.Format(time.RFC3339), "4", fmtFloat(cpu), fmtFloat(mem), "0", "0", "0", fmtFloat(disk), "0", "0", "0"}
+}
+
+func fmtFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func TestComputeDigestSummarizesSamplesInWindow(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("DIRECTORY_PATH", dir+string(os.PathSeparator))
+
+	since := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	writeMetricsCSV(t, dir, "2026-07-01", [][]string{
+		row(since.Add(time.Minute), 10, 20, 30),
+		row(since.Add(2*time.Minute), 90, 80, 70),
+	})
+
+	stats, err := computeDigest(since)
+	if err != nil {
+		t.Fatalf("computeDigest: %v", err)
+	}
+	if stats.Samples != 2 {
+		t.Fatalf("expected 2 samples, got %d", stats.Samples)
+	}
+	if stats.CPUMin != 10 || stats.CPUMax != 90 || stats.CPUAvg != 50 {
+		t.Fatalf("unexpected CPU stats: min=%v max=%v avg=%v", stats.CPUMin, stats.CPUMax, stats.CPUAvg)
+	}
+	if stats.MemMin != 20 || stats.MemMax != 80 {
+		t.Fatalf("unexpected memory stats: min=%v max=%v", stats.MemMin, stats.MemMax)
+	}
+	if stats.DiskMin != 30 || stats.DiskMax != 70 {
+		t.Fatalf("unexpected disk stats: min=%v max=%v", stats.DiskMin, stats.DiskMax)
+	}
+}
+
+func TestComputeDigestSpansMidnightAcrossRollingFiles(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("DIRECTORY_PATH", dir+string(os.PathSeparator))
+
+	yesterday := time.Date(2026, 6, 30, 23, 30, 0, 0, time.UTC)
+	today := time.Date(2026, 7, 1, 0, 30, 0, 0, time.UTC)
+	writeMetricsCSV(t, dir, "2026-06-30", [][]string{row(yesterday, 20, 20, 20)})
+	writeMetricsCSV(t, dir, "2026-07-01", [][]string{row(today, 60, 60, 60)})
+
+	stats, err := computeDigest(yesterday.Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("computeDigest: %v", err)
+	}
+	if stats.Samples != 2 {
+		t.Fatalf("expected samples from both rolling files, got %d", stats.Samples)
+	}
+	if stats.CPUMin != 20 || stats.CPUMax != 60 {
+		t.Fatalf("expected samples from both days folded in, got min=%v max=%v", stats.CPUMin, stats.CPUMax)
+	}
+	if len(stats.Files) != 2 {
+		t.Fatalf("expected both rolling CSVs recorded for attachment, got %v", stats.Files)
+	}
+}
+
+func TestComputeDigestErrorsWhenNoSamplesInWindow(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("DIRECTORY_PATH", dir+string(os.PathSeparator))
+
+	old := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	writeMetricsCSV(t, dir, "2020-01-01", [][]string{row(old, 10, 10, 10)})
+
+	if _, err := computeDigest(time.Now()); err == nil {
+		t.Fatalf("expected error when no samples fall within the window")
+	}
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/cpu"
+	"github.com/shirou/gopsutil/v4/disk"
+	"github.com/shirou/gopsutil/v4/mem"
+)
+
+// runHTTPAPI starts the optional query API, turning the tool from a pure
+// push-cron into a queryable local agent. Every request must carry the
+// token configured via HTTP_API_TOKEN.
+func runHTTPAPI() {
+	addr := os.Getenv("HTTP_API_ADDR")
+	if addr == "" {
+		addr = ":8080"
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cpu", withAuth(handleCPU))
+	mux.HandleFunc("/memory", withAuth(handleMemory))
+	mux.HandleFunc("/disk", withAuth(handleDisk))
+	mux.HandleFunc("/metrics", withAuth(handleMetrics))
+	mux.HandleFunc("/history", withAuth(handleHistory))
+
+	log.Printf("Serving HTTP query API on %s\n", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		log.Printf("http-api server failed: %v\n", err)
+	}
+}
+
+// withAuth rejects requests that don't present the shared HTTP_API_TOKEN as
+// a bearer token.
+func withAuth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := os.Getenv("HTTP_API_TOKEN")
+		if token == "" {
+			http.Error(w, "HTTP_API_TOKEN is not configured", http.StatusInternalServerError)
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
+
+func handleCPU(w http.ResponseWriter, r *http.Request) {
+	perCPU := r.URL.Query().Get("per_cpu") == "true"
+
+	percent, err := cpu.Percent(0, perCPU)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	count, err := cpu.Counts(true)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	payload := map[string]any{"cpu_count": count}
+	if perCPU {
+		payload["per_cpu_usage"] = percent
+	} else {
+		payload["cpu_usage"] = percent[0]
+	}
+	writeJSON(w, payload)
+}
+
+// handleMemory reads memory stats directly rather than through
+// getSystemMetrics, which would also block this request on a 1-second CPU
+// sample it doesn't need.
+func handleMemory(w http.ResponseWriter, r *http.Request) {
+	memoryUsage, err := mem.VirtualMemory()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, map[string]any{
+		"memory_usage": memoryUsage.UsedPercent,
+		"memory_total": memoryUsage.Total,
+		"memory_free":  memoryUsage.Free,
+		"memory_used":  memoryUsage.Used,
+	})
+}
+
+// handleDisk reads disk stats directly rather than through
+// getSystemMetrics, which would also block this request on a 1-second CPU
+// sample it doesn't need.
+func handleDisk(w http.ResponseWriter, r *http.Request) {
+	diskUsage, err := disk.Usage("/")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, map[string]any{
+		"disk_usage": diskUsage.UsedPercent,
+		"disk_total": diskUsage.Total,
+		"disk_free":  diskUsage.Free,
+		"disk_used":  diskUsage.Used,
+	})
+}
+
+// handleMetrics returns the current SystemMetrics, optionally narrowed to
+// the fields named in ?fields= and encoded as JSON or CSV per ?format=.
+func handleMetrics(w http.ResponseWriter, r *http.Request) {
+	metrics, err := getSystemMetrics()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	row := metricsToRow(metrics)
+	fields := requestedFields(r, row)
+
+	if r.URL.Query().Get("format") == "csv" {
+		writeCSVRows(w, fields, [][]string{rowValues(row, fields)})
+		return
+	}
+
+	filtered := make(map[string]any, len(fields))
+	for _, f := range fields {
+		filtered[f] = row[f]
+	}
+	writeJSON(w, filtered)
+}
+
+// handleHistory streams rows from the rolling CSV reports directory,
+// filtered by the since/until query parameters (RFC3339).
+func handleHistory(w http.ResponseWriter, r *http.Request) {
+	since, until, err := parseHistoryRange(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rows, err := readMetricsRows(since, until)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if r.URL.Query().Get("format") == "csv" {
+		writeCSVRows(w, csvHeader, rows)
+		return
+	}
+
+	records := make([]map[string]string, 0, len(rows))
+	for _, row := range rows {
+		record := make(map[string]string, len(csvHeader))
+		for i, col := range csvHeader {
+			record[col] = row[i]
+		}
+		records = append(records, record)
+	}
+	writeJSON(w, records)
+}
+
+func parseHistoryRange(r *http.Request) (since, until time.Time, err error) {
+	since = time.Unix(0, 0)
+	until = time.Now()
+
+	if v := r.URL.Query().Get("since"); v != "" {
+		since, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			return since, until, fmt.Errorf("invalid since: %w", err)
+		}
+	}
+	if v := r.URL.Query().Get("until"); v != "" {
+		until, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			return since, until, fmt.Errorf("invalid until: %w", err)
+		}
+	}
+	return since, until, nil
+}
+
+// metricsToRow keys each value by the column name (csvHeader) written by
+// saveSystemMetrics.
+func metricsToRow(metrics *SystemMetrics) map[string]string {
+	return map[string]string{
+		"Timestamp":   metrics.Timestamp.Format(time.RFC3339),
+		"CPUCount":    strconv.Itoa(metrics.CPUCount),
+		"CPUUsage":    strconv.FormatFloat(metrics.CPUUsage, 'f', -1, 64),
+		"MemoryUsage": strconv.FormatFloat(metrics.MemoryUsage, 'f', -1, 64),
+		"MemoryTotal": strconv.FormatUint(metrics.MemoryTotal, 10),
+		"MemoryFree":  strconv.FormatUint(metrics.MemoryFree, 10),
+		"MemoryUsed":  strconv.FormatUint(metrics.MemoryUsed, 10),
+		"DiskUsage":   strconv.FormatFloat(metrics.DiskUsage, 'f', -1, 64),
+		"DiskTotal":   strconv.FormatUint(metrics.DiskTotal, 10),
+		"DiskFree":    strconv.FormatUint(metrics.DiskFree, 10),
+		"DiskUsed":    strconv.FormatUint(metrics.DiskUsed, 10),
+	}
+}
+
+// requestedFields returns the ?fields= list, validated against row, or every
+// known field if none was requested.
+func requestedFields(r *http.Request, row map[string]string) []string {
+	raw := r.URL.Query().Get("fields")
+	if raw == "" {
+		return csvHeader
+	}
+
+	var fields []string
+	for _, f := range strings.Split(raw, ",") {
+		if _, ok := row[f]; ok {
+			fields = append(fields, f)
+		}
+	}
+	if len(fields) == 0 {
+		return csvHeader
+	}
+	return fields
+}
+
+func rowValues(row map[string]string, fields []string) []string {
+	values := make([]string, len(fields))
+	for i, f := range fields {
+		values[i] = row[f]
+	}
+	return values
+}
+
+func writeJSON(w http.ResponseWriter, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		log.Printf("Error encoding JSON response: %v\n", err)
+	}
+}
+
+func writeCSVRows(w http.ResponseWriter, header []string, rows [][]string) {
+	w.Header().Set("Content-Type", "text/csv")
+	writer := csv.NewWriter(w)
+	writer.Write(header)
+	writer.WriteAll(rows)
+	writer.Flush()
+}
@@ -1,18 +1,34 @@
 package main
 
 import (
+	"context"
 	"encoding/csv"
+	"flag"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
-	"strconv"
+	"path/filepath"
+	"sort"
 	"time"
 
+	"github.com/daniyalumer/go-collect-system-metrics-cronjob/collector"
+	"github.com/daniyalumer/go-collect-system-metrics-cronjob/pkg/pushmetrics"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/shirou/gopsutil/v4/cpu"
 	"github.com/shirou/gopsutil/v4/disk"
 	"github.com/shirou/gopsutil/v4/mem"
-	gomail "gopkg.in/mail.v2"
+)
+
+// Run mode selection, set via the RUN_MODE env var (default: csv-email).
+const (
+	modeCSVEmail        = "csv-email"
+	modePromScrape      = "prom-scrape"
+	modePromRemoteWrite = "prom-remote-write"
+	modeDaemon          = "daemon"
+	modeHTTPAPI         = "http-api"
 )
 
 type SystemMetrics struct {
@@ -65,21 +81,92 @@ func getSystemMetrics() (*SystemMetrics, error) {
 	}, nil
 }
 
+var csvHeader = []string{"Timestamp", "CPUCount", "CPUUsage", "MemoryUsage", "MemoryTotal", "MemoryFree", "MemoryUsed", "DiskUsage", "DiskTotal", "DiskFree", "DiskUsed"}
+
+// rollingCSVPath returns today's metrics CSV path; one file accumulates all
+// samples collected that day instead of one file per run.
+func rollingCSVPath() string {
+	today := time.Now().Format("2006-01-02")
+	return fmt.Sprintf("%smetrics_%s.csv", os.Getenv("DIRECTORY_PATH"), today)
+}
+
+// metricsFilesGlob matches every rolling daily CSV saveSystemMetrics has
+// ever written, wherever DIRECTORY_PATH points.
+func metricsFilesGlob() string {
+	return os.Getenv("DIRECTORY_PATH") + "metrics_*.csv"
+}
+
+// readMetricsRows reads every rolling CSV matched by metricsFilesGlob and
+// returns the rows timestamped within [since, until], oldest first. A
+// window spanning midnight correctly pulls from more than one day's file.
+func readMetricsRows(since, until time.Time) ([][]string, error) {
+	rows, _, err := readMetricsFiles(since, until)
+	return rows, err
+}
+
+// readMetricsFiles is readMetricsRows plus the subset of matched paths that
+// actually contributed a row in [since, until], oldest first - so a caller
+// that needs to attach the source files (e.g. the email digest) knows
+// exactly which ones the window touched, not just today's.
+func readMetricsFiles(since, until time.Time) (rows [][]string, paths []string, err error) {
+	allPaths, err := filepath.Glob(metricsFilesGlob())
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Strings(allPaths)
+
+	for _, path := range allPaths {
+		file, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		records, err := csv.NewReader(file).ReadAll()
+		file.Close()
+		if err != nil || len(records) <= 1 {
+			continue
+		}
+
+		matched := false
+		for _, row := range records[1:] { // skip header
+			ts, err := time.Parse(time.RFC3339, row[0])
+			if err != nil || ts.Before(since) || ts.After(until) {
+				continue
+			}
+			rows = append(rows, row)
+			matched = true
+		}
+		if matched {
+			paths = append(paths, path)
+		}
+	}
+	return rows, paths, nil
+}
+
+// saveSystemMetrics appends a row to the rolling daily CSV, writing the
+// header only the first time the file is created. Errors are logged, not
+// returned.
 func saveSystemMetrics(metrics *SystemMetrics, fileName string) {
 	if err := os.MkdirAll("./reports", 0755); err != nil {
 		log.Printf("Error creating reports directory: %v\n", err)
 		return
 	}
 
-	file, err := os.Create(fileName)
+	writeHeader := false
+	if info, err := os.Stat(fileName); err != nil || info.Size() == 0 {
+		writeHeader = true
+	}
+
+	file, err := os.OpenFile(fileName, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
-		log.Printf("Error creating metrics file: %v\n", err)
+		log.Printf("Error opening metrics file: %v\n", err)
 		return
 	}
 	defer file.Close()
 
 	writer := csv.NewWriter(file)
-	writer.Write([]string{"Timestamp", "CPUCount", "CPUUsage", "MemoryUsage", "MemoryTotal", "MemoryFree", "MemoryUsed", "DiskUsage", "DiskTotal", "DiskFree", "DiskUsed"})
+	if writeHeader {
+		writer.Write(csvHeader)
+	}
 	err = writer.Write([]string{
 		metrics.Timestamp.Format(time.RFC3339),
 		fmt.Sprintf("%d", metrics.CPUCount),
@@ -100,60 +187,132 @@ func saveSystemMetrics(metrics *SystemMetrics, fileName string) {
 	writer.Flush()
 }
 
-func sendMetricsToEmail(fileName string) {
-	if err := godotenv.Load(); err != nil {
-		log.Printf("Error loading .env file: %v\n", err)
+// runCSVEmail is the default cron-friendly mode: append this run's sample
+// to the rolling daily CSV, then send a digest email if DIGEST_INTERVAL has
+// elapsed since the last one. Most invocations only append; the digest -
+// with summary stats and the CSV attached - goes out on the slower cadence.
+func runCSVEmail() {
+	metrics, err := getSystemMetrics()
+	if err != nil {
+		log.Printf("Error getting system metrics: %v\n", err)
 		return
 	}
+	log.Printf("Metrics: %+v\n", metrics)
+	saveSystemMetrics(metrics, rollingCSVPath())
+	evaluateAlerts(metrics)
 
-	message := gomail.NewMessage()
-	message.SetHeader("From", os.Getenv("SMTP_FROM"))
-	message.SetHeader("To", os.Getenv("SMTP_TO"))
-	message.SetHeader("Subject", "System Metrics")
+	sendDigestIfDue()
+}
 
-	message.Attach(fileName)
+// runPromScrape exposes /metrics for a Prometheus server to scrape, using
+// the collector.Collector set registered against a dedicated registry.
+func runPromScrape() {
+	addr := os.Getenv("PROM_LISTEN_ADDR")
+	if addr == "" {
+		addr = ":9100"
+	}
 
-	smtpPort, err := strconv.Atoi(os.Getenv("SMTP_PORT"))
-	if err != nil {
-		log.Fatalf("Invalid SMTP port: %v", err)
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(collector.NewRegistry(collector.All()...))
+
+	http.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	log.Printf("Serving Prometheus metrics on %s/metrics\n", addr)
+	if err := http.ListenAndServe(addr, nil); err != nil {
+		log.Fatalf("prom-scrape server failed: %v", err)
 	}
+}
 
-	dialer := gomail.NewDialer(
-		os.Getenv("SMTP_HOST"),
-		smtpPort,
-		os.Getenv("SMTP_USER"),
-		os.Getenv("SMTP_PASSWORD"),
-	)
+// runPromRemoteWrite pushes one sample of every collector to a
+// remote_write endpoint on each cron tick.
+func runPromRemoteWrite() {
+	url := os.Getenv("REMOTE_WRITE_URL")
+	if url == "" {
+		log.Println("REMOTE_WRITE_URL is not set")
+		return
+	}
 
-	maxRetries := 3
-	retryDelay := 5 * time.Second
+	client := collector.NewRemoteWriteClient(url, os.Getenv("REMOTE_WRITE_USERNAME"), os.Getenv("REMOTE_WRITE_PASSWORD"))
 
-	for i := 0; i < maxRetries; i++ {
-		if err := dialer.DialAndSend(message); err != nil {
-			log.Printf("Attempt %d: Error sending email: %v\n", i+1, err)
-			if i < maxRetries-1 {
-				log.Printf("Retrying in %v...\n", retryDelay)
-				time.Sleep(retryDelay)
-			}
-		} else {
-			log.Println("Email sent successfully")
-			return
-		}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := client.Push(ctx, collector.All()); err != nil {
+		log.Printf("Error pushing metrics via remote_write: %v\n", err)
+		return
 	}
+	log.Println("Pushed metrics via remote_write")
 }
 
-func main() {
-	currentTime := time.Now().Format("2006-01-02_150405")
-	fileName := fmt.Sprintf("%smetrics_%s.csv", os.Getenv("DIRECTORY_PATH"), currentTime)
+// pushSampleFromMetrics builds a pushmetrics.Sample from a collected
+// SystemMetrics, tagging it with the local hostname.
+func pushSampleFromMetrics(metrics *SystemMetrics) *pushmetrics.Sample {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	return &pushmetrics.Sample{
+		Hostname:    hostname,
+		Timestamp:   metrics.Timestamp,
+		CPUCount:    metrics.CPUCount,
+		CPUUsage:    metrics.CPUUsage,
+		MemoryUsage: metrics.MemoryUsage,
+		MemoryTotal: metrics.MemoryTotal,
+		MemoryFree:  metrics.MemoryFree,
+		MemoryUsed:  metrics.MemoryUsed,
+		DiskUsage:   metrics.DiskUsage,
+		DiskTotal:   metrics.DiskTotal,
+		DiskFree:    metrics.DiskFree,
+		DiskUsed:    metrics.DiskUsed,
+	}
+}
 
+// runGRPCPush streams a single collected sample to a central collector over
+// gRPC, instead of emailing a CSV for this host.
+func runGRPCPush() {
 	metrics, err := getSystemMetrics()
 	if err != nil {
 		log.Printf("Error getting system metrics: %v\n", err)
 		return
 	}
-	log.Printf("Metrics: %+v\n", metrics)
-	saveSystemMetrics(metrics, fileName)
 
-	log.Println("Sending metrics to email")
-	sendMetricsToEmail(fileName)
+	client := pushmetrics.NewClient(pushmetrics.ConfigFromEnv())
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := client.Send(ctx, pushSampleFromMetrics(metrics)); err != nil {
+		log.Printf("Error streaming metrics via gRPC: %v\n", err)
+		return
+	}
+	log.Println("Streamed metrics via gRPC")
+}
+
+func main() {
+	daemonFlag := flag.Bool("daemon", false, "run in long-lived daemon mode instead of one-shot cron mode")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Printf("Error loading .env file: %v\n", err)
+	}
+
+	if *daemonFlag || os.Getenv("RUN_MODE") == modeDaemon {
+		runDaemon()
+		return
+	}
+
+	if os.Getenv("PUSH_MODE") == "grpc" {
+		runGRPCPush()
+		return
+	}
+
+	switch os.Getenv("RUN_MODE") {
+	case modePromScrape:
+		runPromScrape()
+	case modePromRemoteWrite:
+		runPromRemoteWrite()
+	case modeHTTPAPI:
+		runHTTPAPI()
+	default:
+		runCSVEmail()
+	}
 }
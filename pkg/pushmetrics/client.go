@@ -0,0 +1,201 @@
+// Package pushmetrics streams collected system metrics to a central
+// collector endpoint over gRPC, so many hosts can aggregate into one place
+// instead of each emailing its own CSV.
+package pushmetrics
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"time"
+
+	"github.com/daniyalumer/go-collect-system-metrics-cronjob/pkg/pushmetrics/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Sample is the transport-agnostic shape handed to the client; it is
+// marshalled into a proto.MetricsSample right before sending.
+type Sample struct {
+	Hostname    string
+	Timestamp   time.Time
+	CPUCount    int
+	CPUUsage    float64
+	MemoryUsage float64
+	MemoryTotal uint64
+	MemoryFree  uint64
+	MemoryUsed  uint64
+	DiskUsage   float64
+	DiskTotal   uint64
+	DiskFree    uint64
+	DiskUsed    uint64
+}
+
+func (s *Sample) toProto() *proto.MetricsSample {
+	return &proto.MetricsSample{
+		Hostname:        s.Hostname,
+		TimestampUnixMs: s.Timestamp.UnixMilli(),
+		CpuCount:        int32(s.CPUCount),
+		CpuUsage:        s.CPUUsage,
+		MemoryUsage:     s.MemoryUsage,
+		MemoryTotal:     s.MemoryTotal,
+		MemoryFree:      s.MemoryFree,
+		MemoryUsed:      s.MemoryUsed,
+		DiskUsage:       s.DiskUsage,
+		DiskTotal:       s.DiskTotal,
+		DiskFree:        s.DiskFree,
+		DiskUsed:        s.DiskUsed,
+	}
+}
+
+const (
+	ringBufferSize       = 256
+	defaultRingStatePath = "./reports/.pushmetrics_ring.json"
+)
+
+// ClientConfig configures the mTLS connection to the collector endpoint.
+type ClientConfig struct {
+	// Endpoint is the collector's host:port.
+	Endpoint string
+	// CertFile/KeyFile are this host's client certificate and key.
+	CertFile string
+	KeyFile  string
+	// CAFile is the CA used to verify the collector's server certificate.
+	CAFile string
+	// RingStatePath is where undelivered samples are persisted so the
+	// replay buffer survives across one-shot cron invocations, not just
+	// within a single process. PUSH_MODE=grpc is typically run from the
+	// cron path, so without this the buffer would be recreated empty on
+	// every tick and nothing could ever be "replayed".
+	RingStatePath string
+}
+
+// ConfigFromEnv reads a ClientConfig from PUSH_GRPC_* environment variables.
+func ConfigFromEnv() ClientConfig {
+	ringStatePath := os.Getenv("PUSH_GRPC_RING_STATE_FILE")
+	if ringStatePath == "" {
+		ringStatePath = defaultRingStatePath
+	}
+
+	return ClientConfig{
+		Endpoint:      os.Getenv("PUSH_GRPC_ENDPOINT"),
+		CertFile:      os.Getenv("PUSH_GRPC_CERT_FILE"),
+		KeyFile:       os.Getenv("PUSH_GRPC_KEY_FILE"),
+		CAFile:        os.Getenv("PUSH_GRPC_CA_FILE"),
+		RingStatePath: ringStatePath,
+	}
+}
+
+// Client streams samples to the collector endpoint, reconnecting with
+// exponential backoff and replaying anything buffered while disconnected.
+type Client struct {
+	cfg  ClientConfig
+	ring *ringBuffer
+	// dialer overrides how flush connects to cfg.Endpoint; nil (the
+	// default) dials the network normally. Tests set this to dial an
+	// in-memory listener (e.g. grpc/test/bufconn) instead.
+	dialer func(ctx context.Context, addr string) (net.Conn, error)
+}
+
+// NewClient builds a Client for cfg. Call Send on each collected sample;
+// reconnects and replays happen transparently, and undelivered samples are
+// persisted to cfg.RingStatePath so they survive process restarts.
+func NewClient(cfg ClientConfig) *Client {
+	return &Client{cfg: cfg, ring: newRingBuffer(ringBufferSize, cfg.RingStatePath)}
+}
+
+// Send streams sample to the collector. If the connection is down, sample
+// is buffered in the ring buffer and sent on the next successful connect.
+func (c *Client) Send(ctx context.Context, sample *Sample) error {
+	c.ring.push(sample)
+	return c.flush(ctx)
+}
+
+func (c *Client) flush(ctx context.Context) error {
+	creds, err := c.transportCredentials()
+	if err != nil {
+		return fmt.Errorf("load mTLS credentials: %w", err)
+	}
+
+	var lastErr error
+	backoff := time.Second
+	const maxBackoff = 30 * time.Second
+
+	dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(creds), grpc.WithBlock()}
+	if c.dialer != nil {
+		dialOpts = append(dialOpts, grpc.WithContextDialer(c.dialer))
+	}
+
+	for attempt := 0; attempt < 5; attempt++ {
+		conn, err := grpc.DialContext(ctx, c.cfg.Endpoint, dialOpts...)
+		if err != nil {
+			lastErr = err
+			log.Printf("pushmetrics: connect attempt %d failed: %v (retrying in %v)\n", attempt+1, err, backoff)
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			backoff *= 2
+			if backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+			continue
+		}
+
+		pending := c.ring.drain()
+		err = c.replay(ctx, conn, pending)
+		conn.Close()
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		// Put undelivered samples back so the next attempt replays them too.
+		for _, sample := range pending {
+			c.ring.push(sample)
+		}
+	}
+	return fmt.Errorf("pushmetrics: giving up after retries: %w", lastErr)
+}
+
+func (c *Client) replay(ctx context.Context, conn *grpc.ClientConn, pending []*Sample) error {
+	client := proto.NewPushMetricsClient(conn)
+	stream, err := client.Push(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, sample := range pending {
+		if err := stream.Send(sample.toProto()); err != nil {
+			return err
+		}
+	}
+
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+func (c *Client) transportCredentials() (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(c.cfg.CertFile, c.cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load client cert: %w", err)
+	}
+
+	caCert, err := os.ReadFile(c.cfg.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("read CA file: %w", err)
+	}
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("no valid certificates found in %s", c.cfg.CAFile)
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      caPool,
+	}), nil
+}
@@ -0,0 +1,199 @@
+package pushmetrics
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/daniyalumer/go-collect-system-metrics-cronjob/pkg/pushmetrics/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// recordingServer implements proto.PushMetricsServer, recording every
+// sample it receives over a Push stream in arrival order.
+type recordingServer struct {
+	proto.UnimplementedPushMetricsServer
+	mu      sync.Mutex
+	samples []*proto.MetricsSample
+}
+
+func (s *recordingServer) Push(stream proto.PushMetrics_PushServer) error {
+	for {
+		sample, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(&proto.Ack{})
+		}
+		if err != nil {
+			return err
+		}
+		s.mu.Lock()
+		s.samples = append(s.samples, sample)
+		s.mu.Unlock()
+	}
+}
+
+func (s *recordingServer) received() []*proto.MetricsSample {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*proto.MetricsSample(nil), s.samples...)
+}
+
+// selfSignedCert generates a self-signed certificate/key pair good for
+// "localhost", writing both as PEM files under dir. The same cert file
+// doubles as the CA, since a self-signed cert verifies against a pool
+// containing only itself.
+func selfSignedCert(t *testing.T, dir string) (certPath, keyPath string, cert tls.Certificate) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "bufnet"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		// Matches the Client's Endpoint ("bufnet") below, since the gRPC
+		// client derives the TLS ServerName to verify from the dial
+		// target/authority by default.
+		DNSNames:    []string{"bufnet"},
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	certPath = filepath.Join(dir, "cert.pem")
+	keyPath = filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certPath, certPEM, 0644); err != nil {
+		t.Fatalf("write cert: %v", err)
+	}
+	if err := os.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+
+	cert, err = tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatalf("build tls certificate: %v", err)
+	}
+	return certPath, keyPath, cert
+}
+
+// failThenDialBufconn returns a dialer that fails the first failCount
+// connection attempts (simulating the collector being unreachable), then
+// dials listener for every attempt after that.
+func failThenDialBufconn(listener *bufconn.Listener, failCount int) func(ctx context.Context, addr string) (net.Conn, error) {
+	var attempts int
+	var mu sync.Mutex
+	return func(ctx context.Context, addr string) (net.Conn, error) {
+		mu.Lock()
+		attempt := attempts
+		attempts++
+		mu.Unlock()
+
+		if attempt < failCount {
+			return nil, errors.New("collector unreachable")
+		}
+		return listener.DialContext(ctx)
+	}
+}
+
+func TestClientFlushReplaysBufferedSamplesInOrderAfterReconnect(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath, cert := selfSignedCert(t, dir)
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{cert}})))
+	recording := &recordingServer{}
+	proto.RegisterPushMetricsServer(server, recording)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	client := NewClient(ClientConfig{
+		Endpoint: "bufnet",
+		CertFile: certPath,
+		KeyFile:  keyPath,
+		CAFile:   certPath,
+	})
+	// The first two dial attempts fail, simulating the collector being
+	// down; flush must keep the samples buffered and deliver them once a
+	// connection finally succeeds (the blocking dial retries failed
+	// attempts itself).
+	client.dialer = failThenDialBufconn(listener, 2)
+
+	client.ring.push(&Sample{Hostname: "a"})
+	client.ring.push(&Sample{Hostname: "b"})
+	client.ring.push(&Sample{Hostname: "c"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := client.flush(ctx); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	received := recording.received()
+	if len(received) != 3 {
+		t.Fatalf("expected 3 samples delivered, got %d", len(received))
+	}
+	for i, want := range []string{"a", "b", "c"} {
+		if received[i].GetHostname() != want {
+			t.Fatalf("sample %d: got %q, want %q", i, received[i].GetHostname(), want)
+		}
+	}
+
+	if got := client.ring.drain(); len(got) != 0 {
+		t.Fatalf("expected ring buffer empty after successful flush, got %d samples", len(got))
+	}
+}
+
+func TestClientFlushReturnsErrorAndKeepsSampleWhenCollectorStaysUnreachable(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath, _ := selfSignedCert(t, dir)
+
+	client := NewClient(ClientConfig{
+		Endpoint: "bufnet",
+		CertFile: certPath,
+		KeyFile:  keyPath,
+		CAFile:   certPath,
+	})
+	client.dialer = func(ctx context.Context, addr string) (net.Conn, error) {
+		return nil, errors.New("collector unreachable")
+	}
+
+	client.ring.push(&Sample{Hostname: "a"})
+
+	// A short deadline is enough: every dial attempt fails, so the blocking
+	// dial gives up when ctx expires and flush returns that error - it must
+	// not silently drop the buffered sample.
+	ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer cancel()
+	if err := client.flush(ctx); err == nil {
+		t.Fatalf("expected flush to return an error when the collector is unreachable")
+	}
+
+	if got := client.ring.drain(); len(got) != 1 || got[0].Hostname != "a" {
+		t.Fatalf("expected sample still buffered after a failed flush, got %v", got)
+	}
+}
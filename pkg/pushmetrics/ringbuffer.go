@@ -0,0 +1,128 @@
+package pushmetrics
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ringBuffer holds the last N samples collected while the gRPC connection
+// was down, so they can be replayed once it recovers. When path is set, the
+// buffer is persisted to disk on every mutation so the replay survives
+// across one-shot cron invocations, not just within a single long-lived
+// process.
+type ringBuffer struct {
+	mu     sync.Mutex
+	buf    []*Sample
+	size   int
+	cursor int
+	full   bool
+	path   string
+}
+
+// newRingBuffer builds a ring buffer of size, loading any samples
+// persisted at path from a previous invocation (path may be empty to keep
+// the buffer in-memory only).
+func newRingBuffer(size int, path string) *ringBuffer {
+	r := &ringBuffer{buf: make([]*Sample, size), size: size, path: path}
+	r.load()
+	return r
+}
+
+func (r *ringBuffer) load() {
+	if r.path == "" {
+		return
+	}
+
+	data, err := os.ReadFile(r.path)
+	if os.IsNotExist(err) {
+		return
+	}
+	if err != nil {
+		log.Printf("pushmetrics: error reading ring buffer state %s: %v\n", r.path, err)
+		return
+	}
+
+	var samples []*Sample
+	if err := json.Unmarshal(data, &samples); err != nil {
+		log.Printf("pushmetrics: error decoding ring buffer state %s: %v\n", r.path, err)
+		return
+	}
+	for _, s := range samples {
+		if s != nil {
+			r.pushLocked(s)
+		}
+	}
+}
+
+func (r *ringBuffer) push(s *Sample) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pushLocked(s)
+	r.persistLocked()
+}
+
+func (r *ringBuffer) pushLocked(s *Sample) {
+	r.buf[r.cursor] = s
+	r.cursor = (r.cursor + 1) % r.size
+	if r.cursor == 0 {
+		r.full = true
+	}
+}
+
+// drain returns the buffered samples in the order they were collected and
+// empties the buffer.
+func (r *ringBuffer) drain() []*Sample {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var out []*Sample
+	if r.full {
+		out = append(out, r.buf[r.cursor:]...)
+	}
+	out = append(out, r.buf[:r.cursor]...)
+
+	r.buf = make([]*Sample, r.size)
+	r.cursor = 0
+	r.full = false
+
+	filtered := out[:0]
+	for _, s := range out {
+		if s != nil {
+			filtered = append(filtered, s)
+		}
+	}
+
+	r.persistLocked()
+	return filtered
+}
+
+// persistLocked writes the buffer's current contents to r.path. Callers
+// must hold r.mu.
+func (r *ringBuffer) persistLocked() {
+	if r.path == "" {
+		return
+	}
+
+	var out []*Sample
+	if r.full {
+		out = append(out, r.buf[r.cursor:]...)
+	}
+	out = append(out, r.buf[:r.cursor]...)
+
+	data, err := json.Marshal(out)
+	if err != nil {
+		log.Printf("pushmetrics: error encoding ring buffer state: %v\n", err)
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		log.Printf("pushmetrics: error creating ring buffer state dir: %v\n", err)
+		return
+	}
+	if err := os.WriteFile(r.path, data, 0644); err != nil {
+		log.Printf("pushmetrics: error writing ring buffer state %s: %v\n", r.path, err)
+	}
+}
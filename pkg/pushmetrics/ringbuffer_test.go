@@ -0,0 +1,83 @@
+package pushmetrics
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func sampleAt(hostname string) *Sample {
+	return &Sample{Hostname: hostname, Timestamp: time.Unix(0, 0)}
+}
+
+func TestRingBufferDrainReturnsPushOrder(t *testing.T) {
+	r := newRingBuffer(3, "")
+	r.push(sampleAt("a"))
+	r.push(sampleAt("b"))
+	r.push(sampleAt("c"))
+
+	got := r.drain()
+	if len(got) != 3 {
+		t.Fatalf("expected 3 samples, got %d", len(got))
+	}
+	for i, want := range []string{"a", "b", "c"} {
+		if got[i].Hostname != want {
+			t.Fatalf("sample %d: got %q, want %q", i, got[i].Hostname, want)
+		}
+	}
+}
+
+func TestRingBufferDrainEmptiesBuffer(t *testing.T) {
+	r := newRingBuffer(3, "")
+	r.push(sampleAt("a"))
+	r.drain()
+
+	if got := r.drain(); len(got) != 0 {
+		t.Fatalf("expected empty buffer after drain, got %d samples", len(got))
+	}
+}
+
+func TestRingBufferWrapsAndKeepsMostRecent(t *testing.T) {
+	r := newRingBuffer(2, "")
+	r.push(sampleAt("a"))
+	r.push(sampleAt("b"))
+	r.push(sampleAt("c")) // overwrites "a"
+
+	got := r.drain()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 samples after wrap, got %d", len(got))
+	}
+	if got[0].Hostname != "b" || got[1].Hostname != "c" {
+		t.Fatalf("expected [b c] after wrap, got [%s %s]", got[0].Hostname, got[1].Hostname)
+	}
+}
+
+func TestRingBufferPersistsAndReloadsAcrossInstances(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ring.json")
+
+	r1 := newRingBuffer(4, path)
+	r1.push(sampleAt("a"))
+	r1.push(sampleAt("b"))
+
+	r2 := newRingBuffer(4, path)
+	got := r2.drain()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 samples reloaded from disk, got %d", len(got))
+	}
+	if got[0].Hostname != "a" || got[1].Hostname != "b" {
+		t.Fatalf("expected [a b] reloaded in push order, got [%s %s]", got[0].Hostname, got[1].Hostname)
+	}
+}
+
+func TestRingBufferPersistsDrainAsEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ring.json")
+
+	r1 := newRingBuffer(4, path)
+	r1.push(sampleAt("a"))
+	r1.drain()
+
+	r2 := newRingBuffer(4, path)
+	if got := r2.drain(); len(got) != 0 {
+		t.Fatalf("expected drained state to persist as empty, got %d samples", len(got))
+	}
+}